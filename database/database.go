@@ -32,6 +32,19 @@ type (
 		Client         *mongo.Client
 		Database       *mongo.Database
 		CollectionName string
+
+		// SoftDelete opts this repository into treating Inactive as a real
+		// delete: Find, FindOne, FindById, FindByIdList and Aggregate all
+		// transparently filter to Active documents. It defaults to false
+		// so existing repositories keep seeing every document, matching
+		// their current behavior.
+		SoftDelete bool
+
+		beforeCreateHooks []func(ctx context.Context, doc Document) error
+		afterCreateHooks  []func(ctx context.Context, doc Document) error
+		beforeUpdateHooks []func(ctx context.Context, query interface{}, update interface{}) error
+		afterUpdateHooks  []func(ctx context.Context, query interface{}, update interface{}) error
+		beforeDeleteHooks []func(ctx context.Context, id string) error
 	}
 
 	// Every repository's root struct (DeviceRepository => Device,
@@ -86,7 +99,7 @@ func (r *BaseRepository) Disconnect(ctx context.Context) {
 
 // returns all documents that match the provided query
 func (r *BaseRepository) Find(ctx context.Context, query interface{}, rtn interface{}, opts ...*options.FindOptions) error {
-	c, err := r.Database.Collection(r.CollectionName).Find(ctx, query)
+	c, err := r.Database.Collection(r.CollectionName).Find(ctx, r.activeFilter(query))
 	if err != nil {
 		return fmt.Errorf("BaseRepository.Find: %v", err)
 	}
@@ -95,7 +108,7 @@ func (r *BaseRepository) Find(ctx context.Context, query interface{}, rtn interf
 	if err != nil {
 		return fmt.Errorf("BaseRepository.Find - parsing cursor: %v", err)
 	}
-	return nil
+	return runAfterFind(ctx, rtn)
 }
 
 // returns all documents that match the provided query and return the cursor
@@ -109,7 +122,7 @@ func (r *BaseRepository) FindCursor(ctx context.Context, query interface{}, opts
 
 // returns the first document that matches the provided query
 func (r *BaseRepository) FindOne(ctx context.Context, query interface{}, rtn interface{}) error {
-	c := r.Database.Collection(r.CollectionName).FindOne(ctx, query)
+	c := r.Database.Collection(r.CollectionName).FindOne(ctx, r.activeFilter(query))
 
 	err := c.Decode(rtn)
 	if err != nil && err == mongo.ErrNoDocuments {
@@ -118,23 +131,23 @@ func (r *BaseRepository) FindOne(ctx context.Context, query interface{}, rtn int
 	if err != nil {
 		return fmt.Errorf("BaseRepository.FindOne - c.All: %v", err)
 	}
-	return nil
+	return runAfterFind(ctx, rtn)
 }
 
 // returns the first document that has an _id that matches the id parameter
 func (r *BaseRepository) FindById(ctx context.Context, id string, rtn interface{}) error {
 	query := bson.M{"_id": bson.M{"$eq": util.StringToObjectId(id)}}
-	err := r.Database.Collection(r.CollectionName).FindOne(ctx, query).Decode(rtn)
+	err := r.Database.Collection(r.CollectionName).FindOne(ctx, r.activeFilter(query)).Decode(rtn)
 	if err != nil {
 		return fmt.Errorf("find by id on '%v' collection: %v", r.CollectionName, err)
 	}
-	return nil
+	return runAfterFind(ctx, rtn)
 }
 
 // returns all documents where the _id is matches an id specified in the ids []string parameter
 func (r *BaseRepository) FindByIdList(ctx context.Context, ids []string, rtn interface{}) error {
 	query := bson.M{"_id": bson.M{"$in": util.StringsToObjectId(ids)}}
-	c, err := r.Database.Collection(r.CollectionName).Find(ctx, query)
+	c, err := r.Database.Collection(r.CollectionName).Find(ctx, r.activeFilter(query))
 	if err != nil {
 		return fmt.Errorf("find by id on '%v' collection: %v", r.CollectionName, err)
 	}
@@ -143,7 +156,7 @@ func (r *BaseRepository) FindByIdList(ctx context.Context, ids []string, rtn int
 	if err != nil {
 		return fmt.Errorf("BaseRepository.Find - parsing cursor: %v", err)
 	}
-	return nil
+	return runAfterFind(ctx, rtn)
 }
 
 func (r *BaseRepository) FindDistinct(ctx context.Context, fieldName string, query interface{}) ([]interface{}, error) {
@@ -163,7 +176,12 @@ func (r *BaseRepository) Aggregate(ctx context.Context, pipeline interface{}, al
 		AllowDiskUse: util.PtrBool(allowDiskUse),
 	}
 
-	c, err := r.Database.Collection(r.CollectionName).Aggregate(ctx, pipeline, &opts)
+	scoped, err := r.activeMatchStage(pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := r.Database.Collection(r.CollectionName).Aggregate(ctx, scoped, &opts)
 	if err != nil {
 		return nil, fmt.Errorf("BaseRepository.Aggregate: %v", err)
 	}
@@ -173,10 +191,18 @@ func (r *BaseRepository) Aggregate(ctx context.Context, pipeline interface{}, al
 func (r *BaseRepository) Create(ctx context.Context, doc Document, allowDiskUse bool) (*mongo.InsertOneResult, error) {
 	doc.InitializeBaseDoc()
 
+	if err := r.runBeforeCreate(ctx, doc); err != nil {
+		return nil, err
+	}
+
 	op, err := r.Database.Collection(r.CollectionName).InsertOne(ctx, doc)
 	if err != nil {
 		return nil, fmt.Errorf("inserting document into mongodb: %v", err)
 	}
+
+	if err := r.runAfterCreate(ctx, doc); err != nil {
+		return nil, err
+	}
 	return op, nil
 }
 
@@ -184,6 +210,9 @@ func (r *BaseRepository) CreateMany(ctx context.Context, docs []Document, allowD
 	var d []interface{}
 	for _, doc := range docs {
 		doc.InitializeBaseDoc()
+		if err := r.runBeforeCreate(ctx, doc); err != nil {
+			return nil, err
+		}
 		d = append(d, doc)
 	}
 
@@ -191,6 +220,12 @@ func (r *BaseRepository) CreateMany(ctx context.Context, docs []Document, allowD
 	if err != nil {
 		return nil, fmt.Errorf("inserting document into mongodb: %v", err)
 	}
+
+	for _, doc := range docs {
+		if err := r.runAfterCreate(ctx, doc); err != nil {
+			return nil, err
+		}
+	}
 	return op, nil
 }
 
@@ -198,6 +233,10 @@ func (r *BaseRepository) Update(ctx context.Context, query interface{}, update i
 	var op *mongo.UpdateResult
 	var err error
 
+	if err := r.runBeforeUpdate(ctx, query, update); err != nil {
+		return nil, err
+	}
+
 	if !multi {
 		op, err = r.Database.Collection(r.CollectionName).UpdateOne(ctx, query, update)
 		if err != nil {
@@ -209,6 +248,10 @@ func (r *BaseRepository) Update(ctx context.Context, query interface{}, update i
 			return nil, fmt.Errorf("BaseRepository.Update.UpdateMany: %v", err)
 		}
 	}
+
+	if err := r.runAfterUpdate(ctx, query, update); err != nil {
+		return nil, err
+	}
 	return op, nil
 }
 
@@ -242,12 +285,17 @@ func (r *BaseRepository) UpdateById(ctx context.Context, id string, update inter
 		},
 	}
 
+	doc := update
 	if autoSet {
 		update = bson.M{
 			"$set": update,
 		}
 	}
 
+	if err := r.runBeforeUpdate(ctx, q, doc); err != nil {
+		return nil, err
+	}
+
 	opts := &options.UpdateOptions{
 		Upsert: util.PtrBool(false),
 	}
@@ -257,6 +305,9 @@ func (r *BaseRepository) UpdateById(ctx context.Context, id string, update inter
 		return nil, fmt.Errorf("BaseRepository.UpdateById: %w", err)
 	}
 
+	if err := r.runAfterUpdate(ctx, q, doc); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
@@ -279,10 +330,18 @@ func (r *BaseRepository) UpdateByIdList(ctx context.Context, ids []string, updat
 		"$set": update,
 	}
 
+	if err := r.runBeforeUpdate(ctx, q, update); err != nil {
+		return nil, err
+	}
+
 	result, err := r.Database.Collection(r.CollectionName).UpdateMany(ctx, q, u, opts)
 	if err != nil {
 		return nil, fmt.Errorf("BaseRepository.UpdateByIdList: %w", err)
 	}
+
+	if err := r.runAfterUpdate(ctx, q, update); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
@@ -294,6 +353,10 @@ func (r *BaseRepository) DeleteById(ctx context.Context, id string) (*mongo.Dele
 		},
 	}
 
+	if err := r.runBeforeDelete(ctx, id); err != nil {
+		return nil, err
+	}
+
 	op, err := r.Database.Collection(r.CollectionName).DeleteOne(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("BaseRepository.DeleteById: %v", err)
@@ -0,0 +1,189 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Lifecycle hooks that a Document may optionally implement. BaseRepository
+// checks for these via type assertion around the corresponding operation
+// and calls them when present - for example a Device that implements
+// BeforeCreateHook has BeforeCreate called right before it's inserted.
+type (
+	BeforeCreateHook interface {
+		BeforeCreate(ctx context.Context) error
+	}
+	AfterCreateHook interface {
+		AfterCreate(ctx context.Context) error
+	}
+	// BeforeUpdateHook only fires when the update argument passed to
+	// Update/UpdateById/UpdateByIdList is itself a Document - runBeforeUpdate
+	// type-asserts update directly, with no knowledge of its field shape. In
+	// this codebase's usual call pattern, update is a partial bson.M (e.g.
+	// bson.M{"name": "x"}), which never satisfies Document, so a
+	// BeforeUpdateHook implemented on a full document struct silently never
+	// fires for that common case. Only the repository-wide OnBeforeUpdate
+	// closure is guaranteed to run on every update call.
+	BeforeUpdateHook interface {
+		BeforeUpdate(ctx context.Context) error
+	}
+	// AfterUpdateHook has the same limitation as BeforeUpdateHook - it only
+	// fires when update is itself a Document, not a partial bson.M.
+	AfterUpdateHook interface {
+		AfterUpdate(ctx context.Context) error
+	}
+	AfterFindHook interface {
+		AfterFind(ctx context.Context) error
+	}
+)
+
+// OnBeforeCreate registers a hook that runs for every document created
+// through this repository, in addition to (and before) that document's own
+// BeforeCreateHook. Useful for cross-cutting concerns like validation or
+// field encryption that apply to every document a repository handles.
+func (r *BaseRepository) OnBeforeCreate(fn func(ctx context.Context, doc Document) error) {
+	r.beforeCreateHooks = append(r.beforeCreateHooks, fn)
+}
+
+// OnAfterCreate registers a repository-wide hook that runs after a
+// document is inserted, in addition to (and before) that document's own
+// AfterCreateHook.
+func (r *BaseRepository) OnAfterCreate(fn func(ctx context.Context, doc Document) error) {
+	r.afterCreateHooks = append(r.afterCreateHooks, fn)
+}
+
+// OnBeforeUpdate registers a repository-wide hook that runs before any
+// Update, UpdateById or UpdateByIdList call.
+func (r *BaseRepository) OnBeforeUpdate(fn func(ctx context.Context, query interface{}, update interface{}) error) {
+	r.beforeUpdateHooks = append(r.beforeUpdateHooks, fn)
+}
+
+// OnAfterUpdate registers a repository-wide hook that runs after any
+// Update, UpdateById or UpdateByIdList call succeeds.
+func (r *BaseRepository) OnAfterUpdate(fn func(ctx context.Context, query interface{}, update interface{}) error) {
+	r.afterUpdateHooks = append(r.afterUpdateHooks, fn)
+}
+
+// OnBeforeDelete registers a repository-wide hook that runs before
+// DeleteById removes a document.
+func (r *BaseRepository) OnBeforeDelete(fn func(ctx context.Context, id string) error) {
+	r.beforeDeleteHooks = append(r.beforeDeleteHooks, fn)
+}
+
+func (r *BaseRepository) runBeforeCreate(ctx context.Context, doc Document) error {
+	for _, fn := range r.beforeCreateHooks {
+		if err := fn(ctx, doc); err != nil {
+			return fmt.Errorf("BaseRepository.OnBeforeCreate hook: %w", err)
+		}
+	}
+	if h, ok := doc.(BeforeCreateHook); ok {
+		if err := h.BeforeCreate(ctx); err != nil {
+			return fmt.Errorf("BeforeCreateHook: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *BaseRepository) runAfterCreate(ctx context.Context, doc Document) error {
+	for _, fn := range r.afterCreateHooks {
+		if err := fn(ctx, doc); err != nil {
+			return fmt.Errorf("BaseRepository.OnAfterCreate hook: %w", err)
+		}
+	}
+	if h, ok := doc.(AfterCreateHook); ok {
+		if err := h.AfterCreate(ctx); err != nil {
+			return fmt.Errorf("AfterCreateHook: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *BaseRepository) runBeforeUpdate(ctx context.Context, query interface{}, update interface{}) error {
+	for _, fn := range r.beforeUpdateHooks {
+		if err := fn(ctx, query, update); err != nil {
+			return fmt.Errorf("BaseRepository.OnBeforeUpdate hook: %w", err)
+		}
+	}
+	if doc, ok := update.(Document); ok {
+		if h, ok := doc.(BeforeUpdateHook); ok {
+			if err := h.BeforeUpdate(ctx); err != nil {
+				return fmt.Errorf("BeforeUpdateHook: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *BaseRepository) runAfterUpdate(ctx context.Context, query interface{}, update interface{}) error {
+	for _, fn := range r.afterUpdateHooks {
+		if err := fn(ctx, query, update); err != nil {
+			return fmt.Errorf("BaseRepository.OnAfterUpdate hook: %w", err)
+		}
+	}
+	if doc, ok := update.(Document); ok {
+		if h, ok := doc.(AfterUpdateHook); ok {
+			if err := h.AfterUpdate(ctx); err != nil {
+				return fmt.Errorf("AfterUpdateHook: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *BaseRepository) runBeforeDelete(ctx context.Context, id string) error {
+	for _, fn := range r.beforeDeleteHooks {
+		if err := fn(ctx, id); err != nil {
+			return fmt.Errorf("BaseRepository.OnBeforeDelete hook: %w", err)
+		}
+	}
+	return nil
+}
+
+// runAfterFind calls AfterFind on rtn (or each element of rtn, if rtn is a
+// pointer to a slice) for any document that implements AfterFindHook. rtn
+// is a plain interface{} destination (as passed to Find/FindOne/etc.), so
+// reflection is needed to reach its elements generically.
+func runAfterFind(ctx context.Context, rtn interface{}) error {
+	v := reflect.ValueOf(rtn)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil
+	}
+	v = v.Elem()
+
+	switch v.Kind() {
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := runAfterFindOne(ctx, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		if err := runAfterFindOne(ctx, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runAfterFindOne(ctx context.Context, v reflect.Value) error {
+	var iface interface{}
+	switch {
+	case v.Kind() == reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		iface = v.Interface()
+	case v.CanAddr():
+		iface = v.Addr().Interface()
+	default:
+		return nil
+	}
+
+	if h, ok := iface.(AfterFindHook); ok {
+		if err := h.AfterFind(ctx); err != nil {
+			return fmt.Errorf("AfterFindHook: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type hookDoc struct {
+	BaseDoc
+	afterFindCalled int
+}
+
+func (d *hookDoc) AfterFind(ctx context.Context) error {
+	d.afterFindCalled++
+	return nil
+}
+
+type failingAfterFindDoc struct {
+	BaseDoc
+}
+
+func (d *failingAfterFindDoc) AfterFind(ctx context.Context) error {
+	return errors.New("boom")
+}
+
+func TestRunAfterFindStruct(t *testing.T) {
+	doc := &hookDoc{}
+	if err := runAfterFind(context.Background(), doc); err != nil {
+		t.Fatalf("runAfterFind: %v", err)
+	}
+	if doc.afterFindCalled != 1 {
+		t.Fatalf("expected AfterFind to be called once, got %d", doc.afterFindCalled)
+	}
+}
+
+func TestRunAfterFindSlice(t *testing.T) {
+	docs := []*hookDoc{{}, {}, {}}
+	if err := runAfterFind(context.Background(), &docs); err != nil {
+		t.Fatalf("runAfterFind: %v", err)
+	}
+	for i, d := range docs {
+		if d.afterFindCalled != 1 {
+			t.Fatalf("doc %d: expected AfterFind to be called once, got %d", i, d.afterFindCalled)
+		}
+	}
+}
+
+func TestRunAfterFindPropagatesError(t *testing.T) {
+	doc := &failingAfterFindDoc{}
+	if err := runAfterFind(context.Background(), doc); err == nil {
+		t.Fatal("expected an error from a failing AfterFindHook, got nil")
+	}
+}
+
+func TestRunAfterFindIgnoresNonDocuments(t *testing.T) {
+	n := 5
+	if err := runAfterFind(context.Background(), &n); err != nil {
+		t.Fatalf("runAfterFind on a non-struct/slice destination should be a no-op, got %v", err)
+	}
+}
+
+func TestRunBeforeUpdateOnlyFiresForDocumentUpdates(t *testing.T) {
+	r := &BaseRepository{}
+
+	// A partial bson.M update, the common case in this codebase, never
+	// satisfies Document, so a BeforeUpdateHook on it can't fire - there's
+	// nothing to type-assert against.
+	if err := r.runBeforeUpdate(context.Background(), nil, map[string]any{"name": "x"}); err != nil {
+		t.Fatalf("runBeforeUpdate with a non-Document update: %v", err)
+	}
+
+	doc := &hookBeforeUpdateDoc{}
+	if err := r.runBeforeUpdate(context.Background(), nil, doc); err != nil {
+		t.Fatalf("runBeforeUpdate: %v", err)
+	}
+	if !doc.called {
+		t.Fatal("expected BeforeUpdateHook to fire when update is a Document")
+	}
+}
+
+type hookBeforeUpdateDoc struct {
+	BaseDoc
+	called bool
+}
+
+func (d *hookBeforeUpdateDoc) BeforeUpdate(ctx context.Context) error {
+	d.called = true
+	return nil
+}
+
+func TestRepositoryWideHooksAlwaysRun(t *testing.T) {
+	r := &BaseRepository{}
+
+	var beforeCalled, afterCalled bool
+	r.OnBeforeUpdate(func(ctx context.Context, query interface{}, update interface{}) error {
+		beforeCalled = true
+		return nil
+	})
+	r.OnAfterUpdate(func(ctx context.Context, query interface{}, update interface{}) error {
+		afterCalled = true
+		return nil
+	})
+
+	update := map[string]any{"name": "x"}
+	if err := r.runBeforeUpdate(context.Background(), nil, update); err != nil {
+		t.Fatalf("runBeforeUpdate: %v", err)
+	}
+	if err := r.runAfterUpdate(context.Background(), nil, update); err != nil {
+		t.Fatalf("runAfterUpdate: %v", err)
+	}
+
+	if !beforeCalled || !afterCalled {
+		t.Fatal("expected the repository-wide OnBeforeUpdate/OnAfterUpdate hooks to run regardless of the update's type")
+	}
+}
@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/clarkmcc/gomongo/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// activeFilter wraps query with a status filter when SoftDelete is enabled,
+// so every read method enforces the soft-delete contract that DocStatus
+// implies instead of leaving it up to the caller to remember.
+func (r *BaseRepository) activeFilter(query interface{}) interface{} {
+	if !r.SoftDelete {
+		return query
+	}
+	return bson.M{
+		"$and": []interface{}{
+			query,
+			bson.M{"status": bson.M{"$in": []DocStatus{Active}}},
+		},
+	}
+}
+
+// activeMatchStage prepends a $match on Active status to pipeline when
+// SoftDelete is enabled. pipeline is typically a mongo.Pipeline, []bson.D,
+// []bson.M, or bson.A - all four are handled here. An unrecognized shape
+// returns an error instead of silently passing pipeline through unmodified,
+// since doing so would leak Inactive documents through Aggregate without
+// any indication the SoftDelete filter never applied.
+func (r *BaseRepository) activeMatchStage(pipeline interface{}) (interface{}, error) {
+	if !r.SoftDelete {
+		return pipeline, nil
+	}
+
+	stage := bson.D{{Key: "$match", Value: bson.D{{Key: "status", Value: bson.M{"$in": []DocStatus{Active}}}}}}
+	match := bson.M{"$match": bson.M{"status": bson.M{"$in": []DocStatus{Active}}}}
+	switch p := pipeline.(type) {
+	case mongo.Pipeline:
+		return append(mongo.Pipeline{stage}, p...), nil
+	case []bson.D:
+		return append([]bson.D{stage}, p...), nil
+	case []bson.M:
+		return append([]bson.M{match}, p...), nil
+	case bson.A:
+		return append(bson.A{match}, p...), nil
+	default:
+		return nil, fmt.Errorf("BaseRepository.activeMatchStage: unsupported pipeline type %T", pipeline)
+	}
+}
+
+// FindIncludingInactive runs Find without the SoftDelete filter, regardless
+// of whether SoftDelete is enabled on this repository. Use it when a caller
+// genuinely needs to see Inactive documents too.
+func (r *BaseRepository) FindIncludingInactive(ctx context.Context, query interface{}, rtn interface{}) error {
+	c, err := r.Database.Collection(r.CollectionName).Find(ctx, query)
+	if err != nil {
+		return fmt.Errorf("BaseRepository.FindIncludingInactive: %v", err)
+	}
+
+	err = c.All(ctx, rtn)
+	if err != nil {
+		return fmt.Errorf("BaseRepository.FindIncludingInactive - parsing cursor: %v", err)
+	}
+	return runAfterFind(ctx, rtn)
+}
+
+// SoftDeleteById marks a document Inactive instead of removing it, which is
+// what DeleteById should arguably do on a SoftDelete repository - kept as a
+// separate method so existing DeleteById callers keep their hard-delete
+// behavior.
+func (r *BaseRepository) SoftDeleteById(ctx context.Context, id string) (*mongo.UpdateResult, error) {
+	filter := bson.M{"_id": bson.M{"$eq": util.StringToObjectId(id)}}
+	update := bson.M{"$set": bson.M{
+		"status":       Inactive,
+		"modifiedDate": time.Now(),
+	}}
+
+	if err := r.runBeforeDelete(ctx, id); err != nil {
+		return nil, err
+	}
+
+	result, err := r.Database.Collection(r.CollectionName).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, fmt.Errorf("BaseRepository.SoftDeleteById: %w", err)
+	}
+	return result, nil
+}
+
+// RestoreById reverses a prior SoftDeleteById call, setting status back to
+// Active.
+func (r *BaseRepository) RestoreById(ctx context.Context, id string) (*mongo.UpdateResult, error) {
+	filter := bson.M{"_id": bson.M{"$eq": util.StringToObjectId(id)}}
+	update := bson.M{"$set": bson.M{
+		"status":       Active,
+		"modifiedDate": time.Now(),
+	}}
+
+	result, err := r.Database.Collection(r.CollectionName).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, fmt.Errorf("BaseRepository.RestoreById: %w", err)
+	}
+	return result, nil
+}
+
+// PurgeInactive hard-deletes every Inactive document whose ModifiedDate is
+// older than olderThan, for callers that want to reclaim storage for
+// documents that have been soft-deleted for a while.
+func (r *BaseRepository) PurgeInactive(ctx context.Context, olderThan time.Duration) (*mongo.DeleteResult, error) {
+	filter := bson.M{
+		"status":       Inactive,
+		"modifiedDate": bson.M{"$lt": time.Now().Add(-olderThan)},
+	}
+
+	result, err := r.Database.Collection(r.CollectionName).DeleteMany(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("BaseRepository.PurgeInactive: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestActiveFilter(t *testing.T) {
+	query := bson.M{"name": "foo"}
+
+	r := &BaseRepository{}
+	if got := r.activeFilter(query); !reflect.DeepEqual(got, query) {
+		t.Fatalf("SoftDelete disabled should pass query through unchanged, got %#v", got)
+	}
+
+	r.SoftDelete = true
+	got, ok := r.activeFilter(query).(bson.M)
+	if !ok {
+		t.Fatalf("expected bson.M, got %T", r.activeFilter(query))
+	}
+	and, ok := got["$and"].([]interface{})
+	if !ok || len(and) != 2 {
+		t.Fatalf("expected a 2-element $and, got %#v", got)
+	}
+	if !reflect.DeepEqual(and[0], query) {
+		t.Fatalf("expected original query preserved as the first $and clause, got %#v", and[0])
+	}
+}
+
+func TestActiveMatchStagePrependsMatch(t *testing.T) {
+	r := &BaseRepository{SoftDelete: true}
+
+	cases := []struct {
+		name     string
+		pipeline interface{}
+	}{
+		{"mongo.Pipeline", mongo.Pipeline{bson.D{{Key: "$project", Value: bson.M{"name": 1}}}}},
+		{"[]bson.D", []bson.D{{{Key: "$project", Value: bson.M{"name": 1}}}}},
+		{"[]bson.M", []bson.M{{"$project": bson.M{"name": 1}}}},
+		{"bson.A", bson.A{bson.M{"$project": bson.M{"name": 1}}}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			scoped, err := r.activeMatchStage(c.pipeline)
+			if err != nil {
+				t.Fatalf("activeMatchStage: %v", err)
+			}
+
+			v := reflect.ValueOf(scoped)
+			if v.Kind() != reflect.Slice || v.Len() != 2 {
+				t.Fatalf("expected the original stage plus a prepended $match, got %#v", scoped)
+			}
+		})
+	}
+}
+
+func TestActiveMatchStageRejectsUnsupportedType(t *testing.T) {
+	r := &BaseRepository{SoftDelete: true}
+
+	if _, err := r.activeMatchStage("not a pipeline"); err == nil {
+		t.Fatal("expected an error for an unsupported pipeline type, got nil")
+	}
+}
+
+func TestActiveMatchStageDisabled(t *testing.T) {
+	r := &BaseRepository{}
+	pipeline := "anything"
+
+	got, err := r.activeMatchStage(pipeline)
+	if err != nil {
+		t.Fatalf("activeMatchStage: %v", err)
+	}
+	if got != pipeline {
+		t.Fatalf("SoftDelete disabled should pass pipeline through unchanged, got %#v", got)
+	}
+}
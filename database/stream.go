@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// StreamItem is a single document (or error) yielded by Stream. Consumers
+// should check Err before using Doc - a non-nil Err ends the stream.
+type StreamItem[T Document] struct {
+	Doc T
+	Err error
+}
+
+// StreamOptions controls buffering for Stream.
+type StreamOptions struct {
+	// BufferSize sets the channel buffer depth, bounding how far Stream can
+	// get ahead of a slow consumer. Defaults to 0 (unbuffered) when left
+	// at zero, which gives the strongest backpressure.
+	BufferSize int
+	Find       *options.FindOptions
+}
+
+// Stream pulls one document at a time from the cursor instead of loading
+// the whole result set into memory like Find does, so callers can process
+// collections far larger than memory. The returned channel is closed when
+// the cursor is exhausted, ctx is cancelled, or an error occurs - in the
+// error case the last StreamItem sent carries it. The underlying cursor is
+// always closed before the channel closes.
+func (r *TypedRepository[T]) Stream(ctx context.Context, query interface{}, opts StreamOptions) (<-chan StreamItem[T], error) {
+	var findOpts []*options.FindOptions
+	if opts.Find != nil {
+		findOpts = append(findOpts, opts.Find)
+	}
+
+	c, err := r.Database.Collection(r.CollectionName).Find(ctx, r.activeFilter(query), findOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("TypedRepository.Stream: %v", err)
+	}
+
+	items := make(chan StreamItem[T], opts.BufferSize)
+	go func() {
+		defer close(items)
+		defer c.Close(ctx)
+
+		send := func(item StreamItem[T]) bool {
+			select {
+			case items <- item:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for c.Next(ctx) {
+			doc := newT[T]()
+			if err := c.Decode(doc); err != nil {
+				send(StreamItem[T]{Err: fmt.Errorf("TypedRepository.Stream - decoding: %v", err)})
+				return
+			}
+			if err := runAfterFind(ctx, doc); err != nil {
+				send(StreamItem[T]{Err: err})
+				return
+			}
+
+			if !send(StreamItem[T]{Doc: doc}) {
+				return
+			}
+		}
+		if err := c.Err(); err != nil {
+			send(StreamItem[T]{Err: fmt.Errorf("TypedRepository.Stream - cursor: %v", err)})
+		}
+	}()
+	return items, nil
+}
+
+// ForEach streams query one document at a time and calls fn for each one,
+// stopping (and returning fn's error) the first time fn fails, or when ctx
+// is cancelled.
+func (r *TypedRepository[T]) ForEach(ctx context.Context, query interface{}, fn func(doc T) error, opts StreamOptions) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	items, err := r.Stream(ctx, query, opts)
+	if err != nil {
+		return err
+	}
+
+	for item := range items {
+		if item.Err != nil {
+			return item.Err
+		}
+		if err := fn(item.Doc); err != nil {
+			return fmt.Errorf("TypedRepository.ForEach: %w", err)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
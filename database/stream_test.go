@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+type streamTestDoc struct {
+	BaseDoc
+	Name            string `bson:"name"`
+	afterFindCalled int
+}
+
+func (d *streamTestDoc) AfterFind(ctx context.Context) error {
+	d.afterFindCalled++
+	return nil
+}
+
+func newMockTypedRepository(mt *mtest.T, softDelete bool) *TypedRepository[*streamTestDoc] {
+	base := &BaseRepository{
+		Database:       mt.DB,
+		CollectionName: mt.Coll.Name(),
+		SoftDelete:     softDelete,
+	}
+	return NewTypedRepository[*streamTestDoc](base)
+}
+
+func mockFindResponses(mt *mtest.T, docs ...bson.D) {
+	first := mtest.CreateCursorResponse(1, mt.DB.Name()+"."+mt.Coll.Name(), mtest.FirstBatch, docs...)
+	end := mtest.CreateCursorResponse(0, mt.DB.Name()+"."+mt.Coll.Name(), mtest.NextBatch)
+	mt.AddMockResponses(first, end)
+}
+
+func TestStreamDecodesAndRunsAfterFindHook(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("stream", func(mt *mtest.T) {
+		mockFindResponses(mt, bson.D{{Key: "name", Value: "a"}}, bson.D{{Key: "name", Value: "b"}})
+
+		r := newMockTypedRepository(mt, false)
+		items, err := r.Stream(context.Background(), bson.M{}, StreamOptions{})
+		if err != nil {
+			t.Fatalf("Stream: %v", err)
+		}
+
+		var names []string
+		for item := range items {
+			if item.Err != nil {
+				t.Fatalf("stream item error: %v", item.Err)
+			}
+			names = append(names, item.Doc.Name)
+			if item.Doc.afterFindCalled != 1 {
+				t.Fatalf("expected AfterFind to be called once per item, got %d", item.Doc.afterFindCalled)
+			}
+		}
+
+		if len(names) != 2 || names[0] != "a" || names[1] != "b" {
+			t.Fatalf("unexpected stream contents: %v", names)
+		}
+	})
+}
+
+func TestStreamAppliesActiveFilterWhenSoftDeleteEnabled(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("stream", func(mt *mtest.T) {
+		mockFindResponses(mt)
+
+		r := newMockTypedRepository(mt, true)
+		items, err := r.Stream(context.Background(), bson.M{"name": "a"}, StreamOptions{})
+		if err != nil {
+			t.Fatalf("Stream: %v", err)
+		}
+		for range items {
+		}
+
+		evt := mt.GetStartedEvent()
+		if evt == nil || evt.CommandName != "find" {
+			t.Fatalf("expected a find command to have been issued, got %+v", evt)
+		}
+
+		filter := evt.Command.Lookup("filter")
+		if _, ok := filter.Document().Lookup("$and").ArrayOK(); !ok {
+			t.Fatalf("expected the query to be wrapped in the SoftDelete $and filter, got %v", filter)
+		}
+	})
+}
+
+func TestForEachStopsOnCallbackError(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("foreach", func(mt *mtest.T) {
+		mockFindResponses(mt, bson.D{{Key: "name", Value: "a"}})
+
+		r := newMockTypedRepository(mt, false)
+
+		var visited []string
+		boom := errors.New("boom")
+		err := r.ForEach(context.Background(), bson.M{}, func(doc *streamTestDoc) error {
+			visited = append(visited, doc.Name)
+			return boom
+		}, StreamOptions{})
+
+		if err == nil {
+			t.Fatal("expected ForEach to return the callback's error")
+		}
+		if len(visited) != 1 {
+			t.Fatalf("expected ForEach to stop after the first failing callback, visited %v", visited)
+		}
+	})
+}
@@ -0,0 +1,330 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/clarkmcc/gomongo/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type (
+	// TenantDoc is an embeddable mixin for documents that belong to a
+	// specific tenant in a multi-tenant collection. Embed it alongside
+	// BaseDoc, e.g.:
+	//
+	//	type Device struct {
+	//		database.BaseDoc
+	//		database.TenantDoc
+	//		...
+	//	}
+	TenantDoc struct {
+		TenantId string `json:"tenantId" bson:"tenantId"`
+	}
+
+	// TenantRepository wraps a BaseRepository and transparently scopes
+	// the read/write methods it overrides to the tenant returned by
+	// extractor, so callers can't forget to filter by tenant. Methods it
+	// does not override (FindCursor, FindIncludingInactive, UpdateBase,
+	// SoftDeleteById, RestoreById, PurgeInactive, Watch) are promoted
+	// from the embedded BaseRepository and are not tenant-scoped.
+	TenantRepository struct {
+		*BaseRepository
+		extractor func(ctx context.Context) (string, error)
+	}
+)
+
+// NewTenantRepository wraps base with tenant scoping. extractor pulls the
+// current tenant id out of ctx - e.g. reading it from request-scoped
+// auth claims - and is called on every TenantRepository method.
+func NewTenantRepository(base *BaseRepository, extractor func(ctx context.Context) (string, error)) *TenantRepository {
+	return &TenantRepository{BaseRepository: base, extractor: extractor}
+}
+
+// EnsureTenantIndex creates a compound (tenantId, _id) index on the
+// repository's collection, which every tenant-scoped query benefits from.
+func (r *TenantRepository) EnsureTenantIndex(ctx context.Context) error {
+	model := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "tenantId", Value: 1},
+			{Key: "_id", Value: 1},
+		},
+	}
+	if _, err := r.Database.Collection(r.CollectionName).Indexes().CreateOne(ctx, model); err != nil {
+		return fmt.Errorf("TenantRepository.EnsureTenantIndex: %w", err)
+	}
+	return nil
+}
+
+// tenantFilter folds the current tenant id into query as an additional
+// top-level condition.
+func (r *TenantRepository) tenantFilter(ctx context.Context, query interface{}) (interface{}, error) {
+	tenantId, err := r.extractor(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("TenantRepository: resolving tenant id: %w", err)
+	}
+	return bson.M{
+		"$and": []interface{}{
+			query,
+			bson.M{"tenantId": tenantId},
+		},
+	}, nil
+}
+
+func (r *TenantRepository) Find(ctx context.Context, query interface{}, rtn interface{}, opts ...*options.FindOptions) error {
+	filter, err := r.tenantFilter(ctx, query)
+	if err != nil {
+		return err
+	}
+	return r.BaseRepository.Find(ctx, filter, rtn, opts...)
+}
+
+func (r *TenantRepository) FindOne(ctx context.Context, query interface{}, rtn interface{}) error {
+	filter, err := r.tenantFilter(ctx, query)
+	if err != nil {
+		return err
+	}
+	return r.BaseRepository.FindOne(ctx, filter, rtn)
+}
+
+// FindById finds a document by id, refusing (by returning an error that
+// wraps mongo.ErrNoDocuments) to return it if it belongs to a different tenant than the one extractor
+// resolves from ctx.
+func (r *TenantRepository) FindById(ctx context.Context, id string, rtn interface{}) error {
+	tenantId, err := r.extractor(ctx)
+	if err != nil {
+		return fmt.Errorf("TenantRepository: resolving tenant id: %w", err)
+	}
+
+	query := bson.M{"_id": bson.M{"$eq": util.StringToObjectId(id)}, "tenantId": tenantId}
+	err = r.Database.Collection(r.CollectionName).FindOne(ctx, r.activeFilter(query)).Decode(rtn)
+	if err != nil {
+		return fmt.Errorf("TenantRepository.FindById: %w", err)
+	}
+	return runAfterFind(ctx, rtn)
+}
+
+// FindByIdList finds documents by id, silently excluding any that belong to
+// a different tenant than the one extractor resolves from ctx.
+func (r *TenantRepository) FindByIdList(ctx context.Context, ids []string, rtn interface{}) error {
+	tenantId, err := r.extractor(ctx)
+	if err != nil {
+		return fmt.Errorf("TenantRepository: resolving tenant id: %w", err)
+	}
+
+	query := bson.M{"_id": bson.M{"$in": util.StringsToObjectId(ids)}, "tenantId": tenantId}
+	c, err := r.Database.Collection(r.CollectionName).Find(ctx, r.activeFilter(query))
+	if err != nil {
+		return fmt.Errorf("TenantRepository.FindByIdList: %w", err)
+	}
+
+	if err := c.All(ctx, rtn); err != nil {
+		return fmt.Errorf("TenantRepository.FindByIdList - parsing cursor: %w", err)
+	}
+	return runAfterFind(ctx, rtn)
+}
+
+func (r *TenantRepository) FindDistinct(ctx context.Context, fieldName string, query interface{}) ([]interface{}, error) {
+	filter, err := r.tenantFilter(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return r.BaseRepository.FindDistinct(ctx, fieldName, filter)
+}
+
+func (r *TenantRepository) Aggregate(ctx context.Context, pipeline interface{}, allowDiskUse bool) (*mongo.Cursor, error) {
+	tenantId, err := r.extractor(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("TenantRepository: resolving tenant id: %w", err)
+	}
+
+	match := bson.M{"$match": bson.M{"tenantId": tenantId}}
+	switch p := pipeline.(type) {
+	case mongo.Pipeline:
+		stage := bson.D{{Key: "$match", Value: bson.D{{Key: "tenantId", Value: tenantId}}}}
+		pipeline = append(mongo.Pipeline{stage}, p...)
+	case []bson.M:
+		pipeline = append([]bson.M{match}, p...)
+	case bson.A:
+		pipeline = append(bson.A{match}, p...)
+	default:
+		return nil, fmt.Errorf("TenantRepository.Aggregate: unsupported pipeline type %T", pipeline)
+	}
+	return r.BaseRepository.Aggregate(ctx, pipeline, allowDiskUse)
+}
+
+func (r *TenantRepository) Create(ctx context.Context, doc Document, allowDiskUse bool) (*mongo.InsertOneResult, error) {
+	tenantId, err := r.extractor(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("TenantRepository: resolving tenant id: %w", err)
+	}
+	if setter, ok := doc.(interface{ SetTenantId(string) }); ok {
+		setter.SetTenantId(tenantId)
+	}
+	return r.BaseRepository.Create(ctx, doc, allowDiskUse)
+}
+
+func (r *TenantRepository) UpdateById(ctx context.Context, id string, update interface{}, autoSet bool, status []DocStatus) (*mongo.UpdateResult, error) {
+	tenantId, err := r.extractor(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("TenantRepository: resolving tenant id: %w", err)
+	}
+
+	doc := update
+	if autoSet {
+		merged, err := mergeTenantId(update, tenantId)
+		if err != nil {
+			return nil, fmt.Errorf("TenantRepository.UpdateById: %w", err)
+		}
+		update = bson.M{"$set": merged}
+	}
+
+	q := bson.M{
+		"_id":      bson.M{"$eq": util.StringToObjectId(id)},
+		"tenantId": tenantId,
+		"status":   bson.M{"$in": status},
+	}
+
+	if err := r.runBeforeUpdate(ctx, q, doc); err != nil {
+		return nil, err
+	}
+
+	result, err := r.Database.Collection(r.CollectionName).UpdateOne(ctx, q, update, &options.UpdateOptions{Upsert: util.PtrBool(false)})
+	if err != nil {
+		return nil, fmt.Errorf("TenantRepository.UpdateById: %w", err)
+	}
+
+	if err := r.runAfterUpdate(ctx, q, doc); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateByIdList updates the documents among ids that belong to the current
+// tenant, restamping tenantId on the $set payload so an update can't
+// accidentally move a document to a different tenant.
+func (r *TenantRepository) UpdateByIdList(ctx context.Context, ids []string, update interface{}, status []DocStatus) (*mongo.UpdateResult, error) {
+	tenantId, err := r.extractor(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("TenantRepository: resolving tenant id: %w", err)
+	}
+
+	merged, err := mergeTenantId(update, tenantId)
+	if err != nil {
+		return nil, fmt.Errorf("TenantRepository.UpdateByIdList: %w", err)
+	}
+
+	q := bson.M{
+		"_id":      bson.M{"$in": util.StringsToObjectId(ids)},
+		"tenantId": tenantId,
+		"status":   bson.M{"$in": status},
+	}
+
+	if err := r.runBeforeUpdate(ctx, q, update); err != nil {
+		return nil, err
+	}
+
+	result, err := r.Database.Collection(r.CollectionName).UpdateMany(ctx, q, bson.M{"$set": merged}, &options.UpdateOptions{Upsert: util.PtrBool(false)})
+	if err != nil {
+		return nil, fmt.Errorf("TenantRepository.UpdateByIdList: %w", err)
+	}
+
+	if err := r.runAfterUpdate(ctx, q, update); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Update scopes a raw Update/UpdateMany call to the current tenant. Unlike
+// UpdateById/UpdateByIdList, update here is whatever update document/operator
+// the caller passes verbatim, so it's forwarded unmodified - only query is
+// tenant-scoped.
+func (r *TenantRepository) Update(ctx context.Context, query interface{}, update interface{}, multi bool) (*mongo.UpdateResult, error) {
+	filter, err := r.tenantFilter(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return r.BaseRepository.Update(ctx, filter, update, multi)
+}
+
+// CreateMany stamps every doc with the current tenant id before delegating
+// to BaseRepository.CreateMany, the same way Create does for a single doc.
+func (r *TenantRepository) CreateMany(ctx context.Context, docs []Document, allowDiskUse bool) (*mongo.InsertManyResult, error) {
+	tenantId, err := r.extractor(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("TenantRepository: resolving tenant id: %w", err)
+	}
+	for _, doc := range docs {
+		if setter, ok := doc.(interface{ SetTenantId(string) }); ok {
+			setter.SetTenantId(tenantId)
+		}
+	}
+	return r.BaseRepository.CreateMany(ctx, docs, allowDiskUse)
+}
+
+// DeleteById deletes a document by id, refusing to touch it (by matching no
+// documents) if it belongs to a different tenant than the one extractor
+// resolves from ctx.
+func (r *TenantRepository) DeleteById(ctx context.Context, id string) (*mongo.DeleteResult, error) {
+	tenantId, err := r.extractor(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("TenantRepository: resolving tenant id: %w", err)
+	}
+
+	filter := bson.M{"_id": bson.M{"$eq": util.StringToObjectId(id)}, "tenantId": tenantId}
+	if err := r.runBeforeDelete(ctx, id); err != nil {
+		return nil, err
+	}
+
+	op, err := r.Database.Collection(r.CollectionName).DeleteOne(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("TenantRepository.DeleteById: %w", err)
+	}
+	return op, nil
+}
+
+// QuickDeleteById works like DeleteById but doesn't return an op result or
+// an error, matching BaseRepository.QuickDeleteById.
+func (r *TenantRepository) QuickDeleteById(ctx context.Context, id string) {
+	tenantId, err := r.extractor(ctx)
+	if err != nil {
+		log.Printf("TenantRepository.QuickDeleteById: resolving tenant id: %v", err)
+		return
+	}
+
+	filter := bson.M{"_id": bson.M{"$eq": util.StringToObjectId(id)}, "tenantId": tenantId}
+	_, err = r.Database.Collection(r.CollectionName).DeleteOne(ctx, filter)
+	if err != nil {
+		log.Printf("TenantRepository.QuickDeleteById: %v", err)
+	}
+}
+
+// SetTenantId implements the setter interface Create looks for, letting
+// TenantDoc be stamped with the resolved tenant id before insert.
+func (t *TenantDoc) SetTenantId(tenantId string) {
+	t.TenantId = tenantId
+}
+
+// mergeTenantId folds tenantId into update, returning a bson.M with every
+// field update already carried plus tenantId forced to the resolved value.
+// update is round-tripped through bson.Marshal/Unmarshal rather than type
+// switched on bson.M, since callers commonly pass a struct (e.g. a partial
+// Document) rather than a map, and a type switch would silently drop every
+// field on anything that isn't a bson.M.
+func mergeTenantId(update interface{}, tenantId string) (bson.M, error) {
+	raw, err := bson.Marshal(update)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling update: %w", err)
+	}
+
+	m := bson.M{}
+	if err := bson.Unmarshal(raw, &m); err != nil {
+		return nil, fmt.Errorf("unmarshaling update: %w", err)
+	}
+
+	m["tenantId"] = tenantId
+	return m, nil
+}
@@ -0,0 +1,48 @@
+package database
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMergeTenantIdFromBsonM(t *testing.T) {
+	merged, err := mergeTenantId(bson.M{"name": "updated"}, "tenant-1")
+	if err != nil {
+		t.Fatalf("mergeTenantId: %v", err)
+	}
+	if merged["name"] != "updated" {
+		t.Fatalf("expected existing fields to be preserved, got %#v", merged)
+	}
+	if merged["tenantId"] != "tenant-1" {
+		t.Fatalf("expected tenantId to be forced to the resolved value, got %#v", merged["tenantId"])
+	}
+}
+
+type tenantUpdateStruct struct {
+	Name     string `bson:"name"`
+	TenantId string `bson:"tenantId"`
+}
+
+func TestMergeTenantIdFromStructDoesNotDropFields(t *testing.T) {
+	merged, err := mergeTenantId(tenantUpdateStruct{Name: "updated", TenantId: "stale-tenant"}, "tenant-1")
+	if err != nil {
+		t.Fatalf("mergeTenantId: %v", err)
+	}
+	if merged["name"] != "updated" {
+		t.Fatalf("expected struct fields to survive the marshal round-trip, got %#v", merged)
+	}
+	if merged["tenantId"] != "tenant-1" {
+		t.Fatalf("expected tenantId to be overridden to the resolved value, got %#v", merged["tenantId"])
+	}
+}
+
+func TestMergeTenantIdOverridesSpoofedTenantId(t *testing.T) {
+	merged, err := mergeTenantId(bson.M{"tenantId": "someone-elses-tenant"}, "tenant-1")
+	if err != nil {
+		t.Fatalf("mergeTenantId: %v", err)
+	}
+	if merged["tenantId"] != "tenant-1" {
+		t.Fatalf("expected the resolved tenantId to win over a caller-supplied one, got %#v", merged["tenantId"])
+	}
+}
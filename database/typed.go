@@ -0,0 +1,199 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type (
+	// TypedRepository wraps a BaseRepository and decodes results directly
+	// into T instead of requiring callers to pass a destination pointer.
+	// T is typically a pointer type (e.g. *Device) whose underlying struct
+	// embeds BaseDoc and therefore implements Document.
+	TypedRepository[T Document] struct {
+		*BaseRepository
+	}
+
+	// PageOptions controls a single page of a FindPage call. Size defaults
+	// to 20 when left at zero.
+	PageOptions struct {
+		Page    int64
+		Size    int64
+		Sort    interface{}
+		Project interface{}
+	}
+
+	// Page is the result of a FindPage call - a single page of T along with
+	// enough information to know whether another page is available.
+	Page[T any] struct {
+		Items   []T
+		Total   int64
+		Page    int64
+		Size    int64
+		HasMore bool
+	}
+)
+
+// NewTypedRepository wraps an existing BaseRepository with a typed facade.
+// Repositories typically construct one of these in their own
+// NewXRepository function alongside the embedded BaseRepository.
+func NewTypedRepository[T Document](base *BaseRepository) *TypedRepository[T] {
+	return &TypedRepository[T]{BaseRepository: base}
+}
+
+// newT creates a new, non-nil instance of T. T is a pointer type, so the
+// zero value returned by `var t T` is nil and can't be decoded into -
+// reflect.New gives us something to decode into.
+func newT[T Document]() T {
+	var t T
+	rv := reflect.New(reflect.TypeOf(t).Elem())
+	return rv.Interface().(T)
+}
+
+// returns all documents that match the provided query
+func (r *TypedRepository[T]) Find(ctx context.Context, query interface{}, opts ...*options.FindOptions) ([]T, error) {
+	var rtn []T
+	c, err := r.Database.Collection(r.CollectionName).Find(ctx, r.activeFilter(query), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("TypedRepository.Find: %v", err)
+	}
+
+	err = c.All(ctx, &rtn)
+	if err != nil {
+		return nil, fmt.Errorf("TypedRepository.Find - parsing cursor: %v", err)
+	}
+	return rtn, runAfterFind(ctx, &rtn)
+}
+
+// returns the first document that matches the provided query
+func (r *TypedRepository[T]) FindOne(ctx context.Context, query interface{}) (T, error) {
+	rtn := newT[T]()
+	err := r.Database.Collection(r.CollectionName).FindOne(ctx, r.activeFilter(query)).Decode(rtn)
+	if err != nil && err == mongo.ErrNoDocuments {
+		var zero T
+		return zero, nil
+	}
+	if err != nil {
+		return rtn, fmt.Errorf("TypedRepository.FindOne: %v", err)
+	}
+	return rtn, runAfterFind(ctx, rtn)
+}
+
+// returns the first document that has an _id that matches the id parameter
+func (r *TypedRepository[T]) FindById(ctx context.Context, id string) (T, error) {
+	rtn := newT[T]()
+	err := r.BaseRepository.FindById(ctx, id, rtn)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return rtn, nil
+}
+
+// returns all documents where the _id matches an id specified in the ids []string parameter
+func (r *TypedRepository[T]) FindByIdList(ctx context.Context, ids []string) ([]T, error) {
+	var rtn []T
+	err := r.BaseRepository.FindByIdList(ctx, ids, &rtn)
+	if err != nil {
+		return nil, err
+	}
+	return rtn, nil
+}
+
+func (r *TypedRepository[T]) Aggregate(ctx context.Context, pipeline interface{}, allowDiskUse bool) ([]T, error) {
+	c, err := r.BaseRepository.Aggregate(ctx, pipeline, allowDiskUse)
+	if err != nil {
+		return nil, err
+	}
+
+	var rtn []T
+	err = c.All(ctx, &rtn)
+	if err != nil {
+		return nil, fmt.Errorf("TypedRepository.Aggregate - parsing cursor: %v", err)
+	}
+	return rtn, nil
+}
+
+// FindPage runs query with the Skip/Limit implied by opts alongside a
+// CountDocuments call for the same query (the two run concurrently), so
+// callers get both a page of results and the total count in one call instead
+// of hand-rolling
+// the same Skip/Limit/Count boilerplate in every caller.
+func (r *TypedRepository[T]) FindPage(ctx context.Context, query interface{}, opts PageOptions) (Page[T], error) {
+	opts = normalizePageOptions(opts)
+
+	findOpts := options.Find().SetSkip(opts.Page * opts.Size).SetLimit(opts.Size)
+	if opts.Sort != nil {
+		findOpts.SetSort(opts.Sort)
+	}
+	if opts.Project != nil {
+		findOpts.SetProjection(opts.Project)
+	}
+
+	filter := r.activeFilter(query)
+
+	var items []T
+	var total int64
+	var findErr, countErr error
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		c, err := r.Database.Collection(r.CollectionName).Find(ctx, filter, findOpts)
+		if err != nil {
+			findErr = fmt.Errorf("TypedRepository.FindPage - find: %v", err)
+			return
+		}
+		if err := c.All(ctx, &items); err != nil {
+			findErr = fmt.Errorf("TypedRepository.FindPage - parsing cursor: %v", err)
+			return
+		}
+		findErr = runAfterFind(ctx, &items)
+	}()
+	go func() {
+		defer wg.Done()
+		n, err := r.Database.Collection(r.CollectionName).CountDocuments(ctx, filter)
+		if err != nil {
+			countErr = fmt.Errorf("TypedRepository.FindPage - count: %v", err)
+			return
+		}
+		total = n
+	}()
+	wg.Wait()
+
+	if findErr != nil {
+		return Page[T]{}, findErr
+	}
+	if countErr != nil {
+		return Page[T]{}, countErr
+	}
+
+	return Page[T]{
+		Items:   items,
+		Total:   total,
+		Page:    opts.Page,
+		Size:    opts.Size,
+		HasMore: hasMorePages(opts.Page, opts.Size, total),
+	}, nil
+}
+
+// normalizePageOptions fills in PageOptions defaults - Size defaults to 20
+// when left at zero (or below).
+func normalizePageOptions(opts PageOptions) PageOptions {
+	if opts.Size <= 0 {
+		opts.Size = 20
+	}
+	return opts
+}
+
+// hasMorePages reports whether a page of the given size/total has a
+// successor page beyond it.
+func hasMorePages(page, size, total int64) bool {
+	return (page+1)*size < total
+}
@@ -0,0 +1,61 @@
+package database
+
+import "testing"
+
+type typedTestDoc struct {
+	BaseDoc
+	Name string `bson:"name"`
+}
+
+func TestNewTReturnsNonNilPointer(t *testing.T) {
+	doc := newT[*typedTestDoc]()
+	if doc == nil {
+		t.Fatal("expected a non-nil instance, got nil")
+	}
+	doc.Name = "ok"
+	if doc.Name != "ok" {
+		t.Fatalf("expected a usable, addressable instance, got %+v", doc)
+	}
+}
+
+func TestNormalizePageOptionsDefaultsSize(t *testing.T) {
+	cases := []struct {
+		name string
+		size int64
+		want int64
+	}{
+		{"zero", 0, 20},
+		{"negative", -5, 20},
+		{"positive passes through", 50, 50},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := normalizePageOptions(PageOptions{Size: c.size}).Size
+			if got != c.want {
+				t.Fatalf("Size %d: expected %d, got %d", c.size, c.want, got)
+			}
+		})
+	}
+}
+
+func TestHasMorePages(t *testing.T) {
+	cases := []struct {
+		name              string
+		page, size, total int64
+		want              bool
+	}{
+		{"first page, more remain", 0, 20, 25, true},
+		{"last page exactly full", 0, 25, 25, false},
+		{"last page, partially full", 1, 20, 25, false},
+		{"empty collection", 0, 20, 0, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := hasMorePages(c.page, c.size, c.total); got != c.want {
+				t.Fatalf("hasMorePages(%d, %d, %d) = %v, want %v", c.page, c.size, c.total, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// EnsureIndexes creates every index in models on collection if it doesn't
+// already exist. It's a building block for writing Up steps that evolve a
+// collection's indexes without having to touch the mongo driver directly.
+func EnsureIndexes(ctx context.Context, db *mongo.Database, collection string, models []mongo.IndexModel) error {
+	if _, err := db.Collection(collection).Indexes().CreateMany(ctx, models); err != nil {
+		return fmt.Errorf("migrate: ensuring indexes on %q: %w", collection, err)
+	}
+	return nil
+}
+
+// RenameField renames a field from -> to on every document in collection,
+// for writing Up/Down steps that evolve a document's shape.
+func RenameField(ctx context.Context, db *mongo.Database, collection string, from string, to string) error {
+	update := bson.M{"$rename": bson.M{from: to}}
+	if _, err := db.Collection(collection).UpdateMany(ctx, bson.M{}, update); err != nil {
+		return fmt.Errorf("migrate: renaming field %q to %q on %q: %w", from, to, collection, err)
+	}
+	return nil
+}
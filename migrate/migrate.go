@@ -0,0 +1,210 @@
+// The migrate package lets a service register ordered, versioned migrations
+// and run them idempotently against a database. Applied versions are
+// recorded in a `_migrations` collection along with a checksum of the
+// migration (its body when it implements ChecksumProvider, otherwise only
+// its Version), so a version whose implementation has drifted since it was
+// applied can be detected when it supplies a ChecksumProvider.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Migration is a single, idempotent schema/data change. Version must sort
+// lexically in the order migrations should run, e.g. "2023.01.01-0001".
+type Migration interface {
+	Version() string
+	Up(ctx context.Context, db *mongo.Database) error
+	Down(ctx context.Context, db *mongo.Database) error
+}
+
+// ChecksumProvider lets a Migration supply a fingerprint of its own body -
+// e.g. a hash of the SQL/script it runs, or of a literal embedded in the Go
+// source - so the Migrator can tell an already-applied migration's
+// implementation apart from how it looked when it ran. A Migration that
+// doesn't implement this only has its Version checksummed, which can't
+// detect anything beyond the version string itself changing.
+type ChecksumProvider interface {
+	Checksum() string
+}
+
+// MigrationRecord is what gets stored in the _migrations collection for
+// every applied Migration.
+type MigrationRecord struct {
+	Version   string    `bson:"version"`
+	Checksum  string    `bson:"checksum"`
+	AppliedAt time.Time `bson:"appliedAt"`
+}
+
+// Migrator runs a set of registered Migrations against a database.
+type Migrator struct {
+	Database   *mongo.Database
+	migrations []Migration
+}
+
+// NewMigrator creates a Migrator bound to the given database. The
+// _migrations collection is created lazily, when UpTo first records an
+// applied migration.
+func NewMigrator(db *mongo.Database) *Migrator {
+	return &Migrator{Database: db}
+}
+
+func (m *Migrator) collection() *mongo.Collection {
+	return m.Database.Collection("_migrations")
+}
+
+// Register adds one or more Migrations to the Migrator. Migrations don't
+// need to be registered in version order - UpTo sorts by Version before
+// running them.
+func (m *Migrator) Register(migrations ...Migration) {
+	m.migrations = append(m.migrations, migrations...)
+}
+
+// checksum returns a stable fingerprint for a migration: its
+// ChecksumProvider.Checksum() if it implements one, otherwise its Version.
+func checksum(migration Migration) string {
+	fingerprint := migration.Version()
+	if c, ok := migration.(ChecksumProvider); ok {
+		fingerprint = c.Checksum()
+	}
+	sum := sha256.Sum256([]byte(fingerprint))
+	return fmt.Sprintf("%x", sum)
+}
+
+// UpTo runs every registered migration with a Version <= version (in
+// version order) that hasn't already been recorded as applied. Passing ""
+// runs every registered migration. It returns an error without running
+// anything if an already-applied migration's checksum no longer matches
+// what's registered - see ChecksumProvider.
+func (m *Migrator) UpTo(ctx context.Context, version string) error {
+	pending, err := m.pending(ctx, version)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range pending {
+		if err := migration.Up(ctx, m.Database); err != nil {
+			return fmt.Errorf("migrate: running %q up: %w", migration.Version(), err)
+		}
+
+		record := MigrationRecord{
+			Version:   migration.Version(),
+			Checksum:  checksum(migration),
+			AppliedAt: time.Now(),
+		}
+		if _, err := m.collection().InsertOne(ctx, record); err != nil {
+			return fmt.Errorf("migrate: recording %q as applied: %w", migration.Version(), err)
+		}
+	}
+	return nil
+}
+
+// DownTo reverses every applied migration with a Version > version, in
+// reverse version order, removing each one's record as it's undone.
+// Passing "" reverses everything that's been applied.
+func (m *Migrator) DownTo(ctx context.Context, version string) error {
+	applied, err := m.appliedRecords(ctx)
+	if err != nil {
+		return err
+	}
+
+	var toReverse []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version()]; !ok {
+			continue
+		}
+		if version != "" && migration.Version() <= version {
+			continue
+		}
+		toReverse = append(toReverse, migration)
+	}
+	sort.Slice(toReverse, func(i, j int) bool {
+		return toReverse[i].Version() > toReverse[j].Version()
+	})
+
+	for _, migration := range toReverse {
+		if err := migration.Down(ctx, m.Database); err != nil {
+			return fmt.Errorf("migrate: running %q down: %w", migration.Version(), err)
+		}
+		if _, err := m.collection().DeleteOne(ctx, bson.M{"version": migration.Version()}); err != nil {
+			return fmt.Errorf("migrate: removing %q record after down: %w", migration.Version(), err)
+		}
+	}
+	return nil
+}
+
+// pending returns the registered migrations that haven't been applied yet,
+// sorted by Version, optionally capped at an upper bound version.
+func (m *Migrator) pending(ctx context.Context, upTo string) ([]Migration, error) {
+	sorted := make([]Migration, len(m.migrations))
+	copy(sorted, m.migrations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version() < sorted[j].Version()
+	})
+
+	applied, err := m.appliedRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range sorted {
+		if upTo != "" && migration.Version() > upTo {
+			break
+		}
+
+		record, ok := applied[migration.Version()]
+		if !ok {
+			pending = append(pending, migration)
+			continue
+		}
+		if record.Checksum != checksum(migration) {
+			return nil, fmt.Errorf("migrate: %q was already applied but its checksum has changed - bump its Version instead of editing an applied migration", migration.Version())
+		}
+	}
+	return pending, nil
+}
+
+func (m *Migrator) appliedRecords(ctx context.Context) (map[string]MigrationRecord, error) {
+	c, err := m.collection().Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("migrate: listing applied migrations: %w", err)
+	}
+
+	var records []MigrationRecord
+	if err := c.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("migrate: parsing applied migrations: %w", err)
+	}
+
+	applied := make(map[string]MigrationRecord, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// Status returns every applied migration record, most recently applied
+// last.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationRecord, error) {
+	c, err := m.collection().Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("migrate: Status: %w", err)
+	}
+
+	var records []MigrationRecord
+	if err := c.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("migrate: Status - parsing cursor: %w", err)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].AppliedAt.Before(records[j].AppliedAt)
+	})
+	return records, nil
+}
@@ -0,0 +1,53 @@
+package migrate
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type plainMigration struct {
+	version string
+}
+
+func (m plainMigration) Version() string                                    { return m.version }
+func (m plainMigration) Up(ctx context.Context, db *mongo.Database) error   { return nil }
+func (m plainMigration) Down(ctx context.Context, db *mongo.Database) error { return nil }
+
+type checksummedMigration struct {
+	plainMigration
+	body string
+}
+
+func (m checksummedMigration) Checksum() string { return m.body }
+
+func TestChecksumFallsBackToVersion(t *testing.T) {
+	a := plainMigration{version: "2023.01.01-0001"}
+	b := plainMigration{version: "2023.01.01-0001"}
+	if checksum(a) != checksum(b) {
+		t.Fatal("expected two migrations with the same Version to checksum identically")
+	}
+
+	c := plainMigration{version: "2023.01.01-0002"}
+	if checksum(a) == checksum(c) {
+		t.Fatal("expected migrations with different Versions to checksum differently")
+	}
+}
+
+func TestChecksumUsesChecksumProviderWhenAvailable(t *testing.T) {
+	same := checksummedMigration{plainMigration: plainMigration{version: "2023.01.01-0001"}, body: "CREATE INDEX a"}
+	changed := checksummedMigration{plainMigration: plainMigration{version: "2023.01.01-0001"}, body: "CREATE INDEX b"}
+
+	if checksum(same) == checksum(changed) {
+		t.Fatal("expected two migrations with the same Version but different ChecksumProvider bodies to checksum differently")
+	}
+
+	// An unchanged ChecksumProvider body must checksum identically across
+	// calls, so pending() doesn't flag an untouched applied migration as
+	// drifted.
+	untouched := checksummedMigration{plainMigration: plainMigration{version: "2023.01.01-0001"}, body: "CREATE INDEX a"}
+	if checksum(same) != checksum(untouched) {
+		t.Fatal("expected an unchanged migration to checksum identically across calls")
+	}
+}
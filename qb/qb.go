@@ -0,0 +1,157 @@
+// Package qb is a small JSON template engine for composing MongoDB queries
+// and aggregation pipelines declaratively instead of building up bson.M/
+// bson.D literals by hand. A template is valid JSON with {{ }} placeholders
+// evaluated with text/template; the rendered result is parsed as JSON and
+// unmarshaled into the caller's chosen type.
+package qb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"text/template"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	templatesMu sync.RWMutex
+	templates   = map[string]string{}
+)
+
+// Register adds a named sub-template that any template passed to Build can
+// include with {{template "name" .}}. This lets a pipeline be composed out
+// of smaller reusable fragments - a "$match" fragment, a "$lookup"
+// fragment, a "$facet" fragment - the same way the aggregate package's
+// Pipe/Match/Project helpers compose in Go, but declaratively.
+func Register(name string, body string) {
+	templatesMu.Lock()
+	defer templatesMu.Unlock()
+	templates[name] = body
+}
+
+var danglingComma = regexp.MustCompile(`,(\s*[}\]])`)
+
+// Build renders tpl as a Go template against data and unmarshals the
+// resulting extended JSON into T via bson.UnmarshalExtJSON.
+//
+// Two helper functions are available inside a template:
+//
+//	{{ids .fooIds}}     renders a []string as a JSON array of {"$oid": ...} ObjectIDs
+//	{{regex .pattern}} renders a "/pattern/flags" string as {"$regularExpression": {"pattern": ..., "options": ...}}
+//
+// Any data value prefixed with "regex:" (e.g. "regex:/john|jane/i") is
+// expanded the same way before the template runs, so a template can simply
+// write `"name": {{.name}}` and pass `"name": "regex:/john|jane/i"` instead
+// of wiring up the regex func explicitly.
+//
+// {{if .foo}}...{{end}} blocks are common for optional fields, and pruning
+// one can leave a dangling comma behind - Build strips those before
+// parsing the rendered text as JSON.
+func Build[T any](tpl string, data map[string]any) (T, error) {
+	var zero T
+
+	expanded, err := expandRegexValues(data)
+	if err != nil {
+		return zero, fmt.Errorf("qb.Build: %w", err)
+	}
+
+	root := template.New("qb").Funcs(template.FuncMap{
+		"ids":   idsFunc,
+		"regex": regexFunc,
+	})
+
+	templatesMu.RLock()
+	for name, body := range templates {
+		if _, err := root.New(name).Parse(body); err != nil {
+			templatesMu.RUnlock()
+			return zero, fmt.Errorf("qb.Build: parsing sub-template %q: %w", name, err)
+		}
+	}
+	templatesMu.RUnlock()
+
+	root, err = root.Parse(tpl)
+	if err != nil {
+		return zero, fmt.Errorf("qb.Build: parsing template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := root.Execute(&buf, expanded); err != nil {
+		return zero, fmt.Errorf("qb.Build: executing template: %w", err)
+	}
+
+	rendered := danglingComma.ReplaceAll(buf.Bytes(), []byte("$1"))
+
+	var rtn T
+	if err := bson.UnmarshalExtJSON(rendered, false, &rtn); err != nil {
+		return zero, fmt.Errorf("qb.Build: unmarshaling rendered query %s: %w", rendered, err)
+	}
+	return rtn, nil
+}
+
+// expandRegexValues returns a copy of data with every "regex:" prefixed
+// string value replaced by its rendered {"$regularExpression": {...}} JSON
+// (see regexFunc).
+func expandRegexValues(data map[string]any) (map[string]any, error) {
+	expanded := make(map[string]any, len(data))
+	for k, v := range data {
+		if s, ok := v.(string); ok && strings.HasPrefix(s, "regex:") {
+			rendered, err := regexFunc(strings.TrimPrefix(s, "regex:"))
+			if err != nil {
+				return nil, err
+			}
+			expanded[k] = rendered
+			continue
+		}
+		expanded[k] = v
+	}
+	return expanded, nil
+}
+
+// idsFunc renders a []string of hex ids as a JSON array of MongoDB extended
+// JSON ObjectIDs (e.g. {"$oid": "..."}), validating each one along the way.
+// Intended for use in an `"$in": {{ids .fooIds}}` placeholder. Rendering
+// plain quoted hex strings here would make Build's bson.UnmarshalExtJSON
+// decode them as Go strings instead of primitive.ObjectID, breaking any
+// query that compares them against a document's actual _id.
+func idsFunc(ids []string) (string, error) {
+	rendered := make([]string, 0, len(ids))
+	for _, id := range ids {
+		oid, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return "", fmt.Errorf("qb: %q is not a valid ObjectID: %w", id, err)
+		}
+		rendered = append(rendered, fmt.Sprintf(`{"$oid":%q}`, oid.Hex()))
+	}
+	return "[" + strings.Join(rendered, ",") + "]", nil
+}
+
+var regexLiteral = regexp.MustCompile(`^/(.*)/([a-zA-Z]*)$`)
+
+// regexFunc renders a "/pattern/flags" string as the canonical extended
+// JSON form of a BSON regex, {"$regularExpression": {"pattern": ...,
+// "options": ...}} - the legacy {"$regex": ..., "$options": ...} shorthand
+// renders fine as JSON but bson.UnmarshalExtJSON doesn't recognize it as a
+// regex literal, so it'd decode as a plain nested map instead of a
+// primitive.Regex.
+func regexFunc(value string) (string, error) {
+	m := regexLiteral.FindStringSubmatch(value)
+	if m == nil {
+		return "", fmt.Errorf("qb: %q is not a /pattern/flags regex literal", value)
+	}
+
+	pattern, err := json.Marshal(m[1])
+	if err != nil {
+		return "", fmt.Errorf("qb: marshaling regex pattern: %w", err)
+	}
+	options, err := json.Marshal(m[2])
+	if err != nil {
+		return "", fmt.Errorf("qb: marshaling regex options: %w", err)
+	}
+
+	return fmt.Sprintf(`{"$regularExpression": {"pattern": %s, "options": %s}}`, pattern, options), nil
+}
@@ -0,0 +1,43 @@
+package qb
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBuildIdsProducesObjectIDs(t *testing.T) {
+	query, err := Build[bson.M](`{"_id": {"$in": {{ids .ids}}}}`, map[string]any{
+		"ids": []string{"5c7836b73a8de34c78fec399"},
+	})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	in, ok := query["_id"].(bson.M)["$in"].(bson.A)
+	if !ok || len(in) != 1 {
+		t.Fatalf("expected a single-element $in array, got %#v", query["_id"])
+	}
+
+	if _, ok := in[0].(primitive.ObjectID); !ok {
+		t.Fatalf("expected $in element to be a primitive.ObjectID, got %T", in[0])
+	}
+}
+
+func TestBuildRegex(t *testing.T) {
+	query, err := Build[bson.M](`{"name": {{regex .pattern}}}`, map[string]any{
+		"pattern": "/john|jane/i",
+	})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	regex, ok := query["name"].(primitive.Regex)
+	if !ok {
+		t.Fatalf("expected name to be a primitive.Regex, got %T", query["name"])
+	}
+	if regex.Pattern != "john|jane" || regex.Options != "i" {
+		t.Fatalf("unexpected regex: %+v", regex)
+	}
+}